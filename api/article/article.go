@@ -0,0 +1,74 @@
+package article
+
+import (
+	"blog/global"
+	"blog/middleware"
+	"blog/models"
+	"blog/models/res"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateArticle 新建文章。经 SensitiveFilter 命中敏感词且按 pending 策略放行时，
+// 转入待审核队列而不是直接发布
+func (a *Article) CreateArticle(c *gin.Context) {
+	var article models.Article
+	if err := c.ShouldBindJSON(&article); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	if c.GetBool(middleware.PendingReviewKey) {
+		createPendingArticle(c, &article, false)
+		return
+	}
+
+	if err := articleService.CreateArticle(&article); err != nil {
+		global.Log.Error("创建文章失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, article)
+}
+
+// UpdateArticle 更新文章。经 SensitiveFilter 命中敏感词且按 pending 策略放行时，
+// 转入待审核队列而不是直接覆盖已发布内容
+func (a *Article) UpdateArticle(c *gin.Context) {
+	var article models.Article
+	if err := c.ShouldBindJSON(&article); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	if c.GetBool(middleware.PendingReviewKey) {
+		createPendingArticle(c, &article, true)
+		return
+	}
+
+	if err := articleService.UpdateArticle(&article); err != nil {
+		global.Log.Error("更新文章失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, article)
+}
+
+// createPendingArticle 把命中敏感词的文章写入待审核队列，SensitiveHitsKey 取不到时按空列表处理。
+// isUpdate 标记这是对已发布文章的编辑，传给 CreatePending 以便审核通过后走 UpdateArticle 而不是 CreateArticle
+func createPendingArticle(c *gin.Context, article *models.Article, isUpdate bool) {
+	var words []string
+	if hits, ok := c.Get(middleware.SensitiveHitsKey); ok {
+		words, _ = hits.([]string)
+	}
+
+	if err := articleSecurityService.CreatePending(article, words, isUpdate); err != nil {
+		global.Log.Error("创建待审核文章失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, article)
+}
@@ -0,0 +1,142 @@
+package article
+
+import (
+	"strconv"
+
+	"blog/global"
+	"blog/models"
+	"blog/models/res"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Article 文章相关接口：发布与更新、草稿/历史、搜索建议与待审核队列
+type Article struct{}
+
+var articleService = models.NewArticleService()
+
+// CreateDraft 新建草稿
+func (a *Article) CreateDraft(c *gin.Context) {
+	var draft models.ArticleDraft
+	if err := c.ShouldBindJSON(&draft); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	if err := articleService.CreateDraft(&draft); err != nil {
+		global.Log.Error("创建草稿失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, draft)
+}
+
+// UpdateDraft 更新草稿
+func (a *Article) UpdateDraft(c *gin.Context) {
+	var draft models.ArticleDraft
+	if err := c.ShouldBindJSON(&draft); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	if err := articleService.UpdateDraft(&draft); err != nil {
+		global.Log.Error("更新草稿失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, draft)
+}
+
+// ListDrafts 获取当前作者的草稿列表
+func (a *Article) ListDrafts(c *gin.Context) {
+	authorID, err := strconv.ParseUint(c.Query("author_id"), 10, 64)
+	if err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	drafts, err := articleService.ListDrafts(uint(authorID))
+	if err != nil {
+		global.Log.Error("获取草稿列表失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, drafts)
+}
+
+// PublishDraft 发布草稿
+func (a *Article) PublishDraft(c *gin.Context) {
+	draftID := c.Param("id")
+	if draftID == "" {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	article, err := articleService.PublishDraft(draftID)
+	if err != nil {
+		global.Log.Error("发布草稿失败", zap.String("draft_id", draftID), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, article)
+}
+
+// ListArticleHistory 获取文章历史版本列表
+func (a *Article) ListArticleHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	histories, err := articleService.ListArticleHistory(id)
+	if err != nil {
+		global.Log.Error("获取历史版本列表失败", zap.String("id", id), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, histories)
+}
+
+// GetArticleHistory 获取文章某个历史版本
+func (a *Article) GetArticleHistory(c *gin.Context) {
+	id := c.Param("id")
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if id == "" || err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	history, err := articleService.GetArticleHistory(id, version)
+	if err != nil {
+		global.Log.Error("获取历史版本失败", zap.String("id", id), zap.Int64("version", version), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, history)
+}
+
+// RestoreArticleHistory 将文章回滚到某个历史版本
+func (a *Article) RestoreArticleHistory(c *gin.Context) {
+	id := c.Param("id")
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if id == "" || err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	if err := articleService.RestoreArticleHistory(id, version); err != nil {
+		global.Log.Error("恢复历史版本失败", zap.String("id", id), zap.Int64("version", version), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, nil)
+}
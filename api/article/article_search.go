@@ -0,0 +1,35 @@
+package article
+
+import (
+	"blog/global"
+	"blog/models/res"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Suggest 文章标题搜索建议，支持搜索框边输入边联想。未登录访问时 callerID 为 0，
+// 只会联想出所有人可读的文章标题
+func (a *Article) Suggest(c *gin.Context) {
+	prefix := c.Query("prefix")
+	if prefix == "" {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	var callerID uint
+	if uid, ok := c.Get("user_id"); ok {
+		if v, ok := uid.(uint); ok {
+			callerID = v
+		}
+	}
+
+	suggestions, err := articleService.Suggest(prefix, callerID)
+	if err != nil {
+		global.Log.Error("获取搜索建议失败", zap.String("prefix", prefix), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, suggestions)
+}
@@ -0,0 +1,80 @@
+package article
+
+import (
+	"blog/global"
+	"blog/models"
+	"blog/models/res"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var articleSecurityService = models.NewArticleSecurityService(articleService)
+
+// securityAuditRequest 审核请求体
+type securityAuditRequest struct {
+	Pass   bool   `json:"pass"`
+	Reason string `json:"reason"`
+}
+
+// SecuritySearch 分页查询待审核文章队列
+func (a *Article) SecuritySearch(c *gin.Context) {
+	var params models.SearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	items, total, err := articleSecurityService.SecuritySearch(params)
+	if err != nil {
+		global.Log.Error("查询待审核队列失败", zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, gin.H{"items": items, "total": total})
+}
+
+// SecurityGet 获取一条待审核记录详情
+func (a *Article) SecurityGet(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	item, err := articleSecurityService.SecurityGet(id)
+	if err != nil {
+		global.Log.Error("获取待审核文章失败", zap.String("id", id), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, item)
+}
+
+// SecurityAudit 审核通过或拒绝一条待审核记录
+func (a *Article) SecurityAudit(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	var req securityAuditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		res.Fail(c, res.CodeValidationFail)
+		return
+	}
+
+	auditorID, _ := c.Get("user_id")
+	auditorUID, _ := auditorID.(uint)
+
+	if err := articleSecurityService.SecurityAudit(id, req.Pass, req.Reason, auditorUID); err != nil {
+		global.Log.Error("审核待审核文章失败", zap.String("id", id), zap.Error(err))
+		res.Fail(c, res.CodeInternalError)
+		return
+	}
+
+	res.Success(c, nil)
+}
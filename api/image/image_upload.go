@@ -4,9 +4,7 @@ import (
 	"blog/global"
 	"blog/models"
 	"blog/models/res"
-	"io/fs"
 	"mime/multipart"
-	"os"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -22,33 +20,26 @@ func (i *Image) ImageUpload(c *gin.Context) {
 		return
 	}
 
-	fileList, ok := form.File["images"]
-	if !ok || len(fileList) == 0 {
+	fileList := form.File["images"]
+	videoList := form.File["videos"]
+	if len(fileList) == 0 && len(videoList) == 0 {
 		res.Fail(c, res.CodeValidationFail)
 		return
 	}
 
-	// 2. 确保上传目录存在
-	if err := ensureUploadDir(global.Config.Upload.Path); err != nil {
-		global.Log.Error("创建上传目录失败", zap.Error(err))
-		res.Fail(c, res.CodeInternalError)
-		return
-	}
-
-	// 3. 并发处理文件上传
+	// 2. 并发处理文件上传：内容寻址去重、MIME校验、存储与缩略图均由 ImageModel.Upload 完成
 	var (
 		wg      sync.WaitGroup
 		resList []models.UploadResponse
 		mutex   sync.Mutex
 	)
 
-	for _, file := range fileList {
+	for _, file := range append(append([]*multipart.FileHeader{}, fileList...), videoList...) {
 		wg.Add(1)
 		go func(file *multipart.FileHeader) {
 			defer wg.Done()
 
-			// 处理单个文件上传
-			serviceRes := processFileUpload(c, file)
+			serviceRes := (&models.ImageModel{}).Upload(file)
 
 			mutex.Lock()
 			resList = append(resList, serviceRes)
@@ -59,31 +50,3 @@ func (i *Image) ImageUpload(c *gin.Context) {
 
 	res.Success(c, resList)
 }
-
-// 确保上传目录存在
-func ensureUploadDir(path string) error {
-	if _, err := os.ReadDir(path); err != nil {
-		return os.MkdirAll(path, fs.ModePerm)
-	}
-	return nil
-}
-
-// 处理单个文件上传
-func processFileUpload(c *gin.Context, file *multipart.FileHeader) models.UploadResponse {
-	serviceRes := (&models.ImageModel{}).Upload(file)
-	if !serviceRes.IsSuccess {
-		return serviceRes
-	}
-
-	if err := c.SaveUploadedFile(file, serviceRes.FileName); err != nil {
-		global.Log.Error("保存上传文件失败",
-			zap.String("filename", file.Filename),
-			zap.Error(err))
-		return models.UploadResponse{
-			IsSuccess: false,
-			Msg:       "文件保存失败",
-		}
-	}
-
-	return serviceRes
-}
\ No newline at end of file
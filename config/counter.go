@@ -0,0 +1,7 @@
+package config
+
+import "time"
+
+type Counter struct {
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // 计数器从 Redis 刷新到 ES 的周期，默认 30s
+}
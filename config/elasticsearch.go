@@ -0,0 +1,7 @@
+package config
+
+type ElasticSearch struct {
+	Addresses      []string `mapstructure:"addresses"`
+	IndexAnalyzer  string   `mapstructure:"index_analyzer"`  // 索引分词器，默认 ik_max_word，IK插件未安装时回退 standard
+	SearchAnalyzer string   `mapstructure:"search_analyzer"` // 查询分词器，默认 ik_smart，IK插件未安装时回退 standard
+}
@@ -0,0 +1,8 @@
+package config
+
+type Sensitive struct {
+	TitleSensitives   []string `mapstructure:"title_sensitives"`   // 标题敏感词
+	ContentSensitives []string `mapstructure:"content_sensitives"` // 正文敏感词
+	Action            string   `mapstructure:"action"`             // 命中后的处理方式：reject | pending，默认 pending
+	FreezeThreshold   int      `mapstructure:"freeze_threshold"`   // 累计命中次数达到阈值后冻结用户，0 表示不冻结
+}
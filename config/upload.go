@@ -0,0 +1,26 @@
+package config
+
+type Upload struct {
+	Path            string           `mapstructure:"path"`
+	Driver          string           `mapstructure:"driver"`           // local | s3 | qiniu，默认 local
+	ThumbnailWidths []int            `mapstructure:"thumbnail_widths"` // 需要生成的缩略图宽度列表
+	ThumbnailFormat string           `mapstructure:"thumbnail_format"` // 缩略图编码格式，目前只支持 jpeg，默认 jpeg
+	MaxSize         map[string]int64 `mapstructure:"max_size"`         // MIME 类型 -> 单文件字节数上限
+	S3              S3               `mapstructure:"s3"`
+	Qiniu           Qiniu            `mapstructure:"qiniu"`
+}
+
+type S3 struct {
+	Bucket    string `mapstructure:"bucket"`
+	Region    string `mapstructure:"region"`
+	Endpoint  string `mapstructure:"endpoint"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+}
+
+type Qiniu struct {
+	Bucket    string `mapstructure:"bucket"`
+	Domain    string `mapstructure:"domain"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+}
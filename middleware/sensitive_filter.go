@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"blog/global"
+	"blog/models/res"
+	"blog/utils/acmatcher"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PendingReviewKey 命中敏感词但按 pending 策略放行时，写入 gin.Context 的标记
+const PendingReviewKey = "sensitive_pending"
+
+// SensitiveHitsKey 命中的敏感词列表，写入 gin.Context 供下游 handler 使用
+const SensitiveHitsKey = "sensitive_hits"
+
+// config.Sensitive.Action 取值
+const (
+	ActionReject  = "reject"  // 直接拒绝请求
+	ActionPending = "pending" // 放行，转入待审核队列
+)
+
+var (
+	sensitiveMu    sync.RWMutex
+	titleMatcher   *acmatcher.Matcher
+	contentMatcher *acmatcher.Matcher
+	loadOnce       sync.Once
+)
+
+type sensitivePayload struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// LoadSensitiveWords 根据配置重建敏感词自动机，供启动时或后台更新词库后调用。
+// 加锁是因为重建期间可能有请求正在并发读取旧的自动机
+func LoadSensitiveWords() {
+	title := acmatcher.New(global.Config.Sensitive.TitleSensitives)
+	content := acmatcher.New(global.Config.Sensitive.ContentSensitives)
+
+	sensitiveMu.Lock()
+	titleMatcher = title
+	contentMatcher = content
+	sensitiveMu.Unlock()
+}
+
+// ensureSensitiveWordsLoaded 保证自动机在首次使用前已经构建一次，sync.Once 避免
+// 并发请求同时触发构建时产生竞态
+func ensureSensitiveWordsLoaded() {
+	loadOnce.Do(LoadSensitiveWords)
+}
+
+// currentMatchers 以读锁获取当前自动机，与 LoadSensitiveWords 的重建互斥
+func currentMatchers() (*acmatcher.Matcher, *acmatcher.Matcher) {
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+	return titleMatcher, contentMatcher
+}
+
+// SensitiveFilter 扫描文章/评论创建、更新请求体中的标题与正文。
+// 命中后按 config.Sensitive.Action 拒绝请求，或放行给下游 handler 写入待审核队列。
+func SensitiveFilter() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ensureSensitiveWordsLoaded()
+
+		body, err := c.GetRawData()
+		if err != nil {
+			global.Log.Error("读取请求体失败", zap.Error(err))
+			res.Fail(c, res.CodeInternalError)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		var payload sensitivePayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			// 请求体不是期望的结构，交给下游 handler 自行处理绑定错误
+			c.Next()
+			return
+		}
+
+		title, content := currentMatchers()
+		hits := title.Match(payload.Title)
+		hits = append(hits, content.Match(payload.Content)...)
+
+		if len(hits) > 0 {
+			global.Log.Warn("命中敏感词", zap.Strings("words", hits))
+
+			if global.Config.Sensitive.Action == ActionReject {
+				res.Fail(c, res.CodeValidationFail)
+				c.Abort()
+				return
+			}
+
+			c.Set(PendingReviewKey, true)
+			c.Set(SensitiveHitsKey, hits)
+		}
+
+		c.Next()
+	}
+}
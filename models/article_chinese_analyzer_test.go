@@ -0,0 +1,48 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"blog/global"
+)
+
+// TestArticleService_ChineseSegmentation 验证 EnsureAnalyzer 返回的索引分词器
+// 能把"黄河"这样的中文短语正确切分成词项，而不是按字拆开。
+// 依赖本地/CI 环境中可用的 Elasticsearch，未配置时自动跳过
+func TestArticleService_ChineseSegmentation(t *testing.T) {
+	if global.Es == nil {
+		t.Skip("未配置 Elasticsearch，跳过集成测试")
+	}
+
+	s := NewArticleService()
+	defer s.Close()
+
+	indexAnalyzer, _ := s.EnsureAnalyzer()
+	if indexAnalyzer == "" {
+		t.Fatal("EnsureAnalyzer 未返回有效的索引分词器名称")
+	}
+
+	resp, err := global.Es.Indices.Analyze().
+		Analyzer(indexAnalyzer).
+		Text("黄河").
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("分析中文短语失败: %v", err)
+	}
+
+	if len(resp.Tokens) == 0 {
+		t.Fatal("期望分词结果非空")
+	}
+
+	found := false
+	for _, token := range resp.Tokens {
+		if token.Token == "黄河" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("期望分词结果中包含完整词\"黄河\"，实际为: %+v", resp.Tokens)
+	}
+}
@@ -18,43 +18,153 @@ import (
 
 // Article 文章模型
 type Article struct {
-	ID            string    `json:"id"`
-	CreatedAt     time.Time `json:"created_at"`     // 创建时间
-	UpdatedAt     time.Time `json:"updated_at"`     // 更新时间
-	Title         string    `json:"title"`          // 文章标题
-	Abstract      string    `json:"abstract"`       // 文章简介
-	Content       string    `json:"content"`        // 文章内容
-	LookCount     uint      `json:"look_count"`     // 浏览量
-	CommentCount  uint      `json:"comment_count"`  // 评论量
-	DiggCount     uint      `json:"digg_count"`     // 点赞量
-	CollectsCount uint      `json:"collects_count"` // 收藏量
-	UserID        uint      `json:"user_id"`        // 用户id
-	UserName      string    `json:"user_name"`      // 用户昵称
-	Category      string    `json:"category"`       // 文章分类
-	CoverID       uint      `json:"cover_id"`       // 封面id
-	CoverURL      string    `json:"cover_url"`      // 封面
-	Version       int64     `json:"version"`        // 版本号
+	ID            string           `json:"id"`
+	CreatedAt     time.Time        `json:"created_at"`     // 创建时间
+	UpdatedAt     time.Time        `json:"updated_at"`     // 更新时间
+	Title         string           `json:"title"`          // 文章标题
+	Abstract      string           `json:"abstract"`       // 文章简介
+	Content       string           `json:"content"`        // 文章内容
+	Section       []ArticleSection `json:"section"`        // 分段内容（图文混排）
+	Images        []string         `json:"images"`         // 图片列表
+	Videos        []Video          `json:"videos"`         // 视频列表
+	Location      Location         `json:"location"`       // 发布地点
+	WhoRead       []uint           `json:"who_read"`       // 可读用户id白名单，为空表示所有人可读
+	WhoReview     []uint           `json:"who_review"`     // 可评论用户id白名单，为空表示所有人可评论
+	LookCount     uint             `json:"look_count"`     // 浏览量
+	CommentCount  uint             `json:"comment_count"`  // 评论量
+	DiggCount     uint             `json:"digg_count"`     // 点赞量
+	CollectsCount uint             `json:"collects_count"` // 收藏量
+	UserID        uint             `json:"user_id"`        // 用户id
+	UserName      string           `json:"user_name"`      // 用户昵称
+	Category      string           `json:"category"`       // 文章分类
+	CoverID       uint             `json:"cover_id"`       // 封面id
+	CoverURL      string           `json:"cover_url"`      // 封面
+	Version       int64            `json:"version"`        // 版本号
 }
 
+// ArticleSection 文章分段内容，支持纯文本段落或带图段落
+type ArticleSection struct {
+	Type  string `json:"type"` // text | image
+	Text  string `json:"text,omitempty"`
+	Image string `json:"image,omitempty"`
+}
+
+// Video 文章内嵌视频
+type Video struct {
+	Url    string `json:"url"`
+	Cover  string `json:"cover"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Location 文章发布地点
+type Location struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+	Descript  string  `json:"descript"`
+}
+
+// 中文分词器，IK 插件未安装时统一回退到 ES 内置的 standard
+const (
+	defaultIndexAnalyzer  = "ik_max_word"
+	defaultSearchAnalyzer = "ik_smart"
+	fallbackAnalyzer      = "standard"
+)
+
 const (
-	articleIndex = "article_index"
-	cacheTTL     = time.Hour * 2
-	batchSize    = 1000
-	timeout      = time.Second * 5
+	articleIndex        = "article_index"
+	articleDraftIndex   = "article_draft_index"
+	articleHistoryIndex = "article_history_index"
+	cacheTTL            = time.Hour * 2
+	suggestCacheTTL     = time.Minute * 5
+	batchSize           = 1000
+	timeout             = time.Second * 5
 )
 
+// 计数器相关：访问/点赞/评论/收藏量先写 Redis，由 CounterFlusher 周期性批量刷新到 ES
+const (
+	counterKeyPrefix            = "article:counter:"
+	counterFieldLookCount       = "look_count"
+	counterFieldDiggCount       = "digg_count"
+	counterFieldCommentCount    = "comment_count"
+	counterFieldCollectsCount   = "collects_count"
+	defaultCounterFlushInterval = time.Second * 30
+)
+
+// 草稿状态
+const (
+	DraftStatusDraft     = "draft"     // 编辑中
+	DraftStatusPublished = "published" // 已发布
+)
+
+// internalCallerID 内部调用（发布草稿、恢复历史版本时的前置读取等）使用的哨兵调用者id，
+// 用于跳过 WhoRead 校验；真实用户id从1开始自增不会与之冲突。
+// 未登录的匿名访问必须使用真实的 0 值，这样才会被 WhoRead 过滤而不是放行
+const internalCallerID = ^uint(0)
+
+// ArticleDraft 文章草稿，发布前可反复编辑而不影响已发布内容
+type ArticleDraft struct {
+	ID        string    `json:"id"`
+	ArticleID string    `json:"article_id"` // 关联的已发布文章id，新建草稿时为空
+	Title     string    `json:"title"`
+	Abstract  string    `json:"abstract"`
+	Content   string    `json:"content"`
+	Category  string    `json:"category"`
+	CoverID   uint      `json:"cover_id"`
+	CoverURL  string    `json:"cover_url"`
+	AuthorID  uint      `json:"author_id"`
+	Status    string    `json:"status"` // draft | published
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ArticleHistory 文章历史版本，UpdateArticle 每次覆盖前的快照。
+// 字段需要与 Article 的可编辑内容保持一致，否则从历史恢复时会丢失 Section/Images 等数据
+type ArticleHistory struct {
+	ArticleID  string           `json:"article_id"`
+	Version    int64            `json:"version"`
+	Title      string           `json:"title"`
+	Abstract   string           `json:"abstract"`
+	Content    string           `json:"content"`
+	Section    []ArticleSection `json:"section"`
+	Images     []string         `json:"images"`
+	Videos     []Video          `json:"videos"`
+	Location   Location         `json:"location"`
+	WhoRead    []uint           `json:"who_read"`
+	WhoReview  []uint           `json:"who_review"`
+	Category   string           `json:"category"`
+	CoverID    uint             `json:"cover_id"`
+	CoverURL   string           `json:"cover_url"`
+	UserID     uint             `json:"user_id"`
+	UserName   string           `json:"user_name"`
+	ArchivedAt time.Time        `json:"archived_at"`
+}
+
 // ArticleServiceInterface 定义文章服务接口
 type ArticleServiceInterface interface {
 	CreateIndex() error
+	EnsureAnalyzer() (string, string)
 	IndexExists() (bool, error)
 	DeleteIndex() error
 	CreateArticle(*Article) error
 	UpdateArticle(*Article) error
 	DeleteArticle(string) error
 	DeleteArticles([]string) error
-	GetArticle(string) (*Article, error)
+	GetArticle(string, uint) (*Article, error)
 	SearchArticles(SearchParams) (*SearchResult, error)
+	Suggest(string, uint) ([]string, error)
 	ArticleExists(string) (bool, error)
+	CreateDraft(*ArticleDraft) error
+	UpdateDraft(*ArticleDraft) error
+	ListDrafts(uint) ([]ArticleDraft, error)
+	PublishDraft(string) (*Article, error)
+	GetArticleHistory(string, int64) (*ArticleHistory, error)
+	ListArticleHistory(string) ([]ArticleHistory, error)
+	RestoreArticleHistory(string, int64) error
+	IncrementDiggCount(string) error
+	IncrementCommentCount(string) error
+	IncrementCollectsCount(string) error
+	Close()
 }
 
 // ArticleService 文章服务
@@ -64,16 +174,49 @@ type ArticleService struct {
 	retryCount int
 	retryDelay time.Duration
 	mu         sync.RWMutex
+	flusher    *CounterFlusher
 }
 
-// NewArticleService 创建文章服务实例
+// NewArticleService 创建文章服务实例，并启动计数器后台刷新协程
 func NewArticleService() *ArticleService {
-	return &ArticleService{
+	s := &ArticleService{
 		ctx:        context.Background(),
 		cache:      global.Redis,
 		retryCount: 3,
 		retryDelay: time.Millisecond * 100,
 	}
+
+	interval := global.Config.Counter.FlushInterval
+	if interval <= 0 {
+		interval = defaultCounterFlushInterval
+	}
+	s.flusher = NewCounterFlusher(s, interval)
+	s.flusher.Start(s.ctx)
+
+	return s
+}
+
+// EnsureAnalyzer 探测 IK 分词插件是否安装，返回实际可用的索引/查询分词器名称。
+// 插件缺失或探测失败时统一回退到 standard，避免建索引直接报错。
+func (s *ArticleService) EnsureAnalyzer() (string, string) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	indexAnalyzer := global.Config.ElasticSearch.IndexAnalyzer
+	if indexAnalyzer == "" {
+		indexAnalyzer = defaultIndexAnalyzer
+	}
+	searchAnalyzer := global.Config.ElasticSearch.SearchAnalyzer
+	if searchAnalyzer == "" {
+		searchAnalyzer = defaultSearchAnalyzer
+	}
+
+	if _, err := global.Es.Indices.Analyze().Analyzer(indexAnalyzer).Text("探测").Do(ctx); err != nil {
+		global.Log.Warn("IK分词插件未安装，回退到standard分词器", zap.Error(err))
+		return fallbackAnalyzer, fallbackAnalyzer
+	}
+
+	return indexAnalyzer, searchAnalyzer
 }
 
 // CreateIndex 创建索引
@@ -96,23 +239,18 @@ func (s *ArticleService) CreateIndex() error {
 		}
 	}
 
-	properties := map[string]types.Property{
-		"title":          types.NewTextProperty(),
-		"abstract":       types.NewTextProperty(),
-		"content":        types.NewTextProperty(),
-		"category":       types.NewKeywordProperty(),
-		"created_at":     types.NewDateProperty(),
-		"updated_at":     types.NewDateProperty(),
-		"look_count":     types.NewIntegerNumberProperty(),
-		"comment_count":  types.NewIntegerNumberProperty(),
-		"digg_count":     types.NewIntegerNumberProperty(),
-		"collects_count": types.NewIntegerNumberProperty(),
-		"user_id":        types.NewIntegerNumberProperty(),
-		"user_name":      types.NewKeywordProperty(),
-		"cover_id":       types.NewIntegerNumberProperty(),
-		"cover_url":      types.NewKeywordProperty(),
-		"version":        types.NewLongNumberProperty(),
-	}
+	indexAnalyzer, searchAnalyzer := s.EnsureAnalyzer()
+
+	properties := s.contentProperties(indexAnalyzer, searchAnalyzer)
+	properties["created_at"] = types.NewDateProperty()
+	properties["updated_at"] = types.NewDateProperty()
+	properties["look_count"] = types.NewIntegerNumberProperty()
+	properties["comment_count"] = types.NewIntegerNumberProperty()
+	properties["digg_count"] = types.NewIntegerNumberProperty()
+	properties["collects_count"] = types.NewIntegerNumberProperty()
+	properties["user_id"] = types.NewIntegerNumberProperty()
+	properties["user_name"] = types.NewKeywordProperty()
+	properties["version"] = types.NewLongNumberProperty()
 
 	_, err = global.Es.Indices.Create(articleIndex).
 		Mappings(&types.TypeMapping{
@@ -124,6 +262,130 @@ func (s *ArticleService) CreateIndex() error {
 		return fmt.Errorf("创建索引失败: %w", err)
 	}
 
+	if err := s.createDraftIndex(indexAnalyzer, searchAnalyzer); err != nil {
+		return err
+	}
+
+	if err := s.createHistoryIndex(indexAnalyzer, searchAnalyzer); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// contentProperties 构建标题/简介/正文/分段/媒体/地理位置/ACL 这部分公共字段映射。
+// article_index、article_draft_index、article_history_index 均基于这套映射，
+// 各自再补充自己特有的字段，这样草稿和历史版本的正文字段类型不会依赖 ES 动态映射猜测
+func (s *ArticleService) contentProperties(indexAnalyzer, searchAnalyzer string) map[string]types.Property {
+	sectionProperty := types.NewNestedProperty()
+	sectionProperty.Properties = map[string]types.Property{
+		"type":  types.NewKeywordProperty(),
+		"text":  types.NewTextProperty(),
+		"image": types.NewKeywordProperty(),
+	}
+
+	videoProperty := types.NewObjectProperty()
+	videoProperty.Properties = map[string]types.Property{
+		"url":    types.NewKeywordProperty(),
+		"cover":  types.NewKeywordProperty(),
+		"width":  types.NewIntegerNumberProperty(),
+		"height": types.NewIntegerNumberProperty(),
+	}
+
+	titleProperty := types.NewTextProperty()
+	titleProperty.Analyzer = &indexAnalyzer
+	titleProperty.SearchAnalyzer = &searchAnalyzer
+	titleKeyword := types.NewKeywordProperty()
+	ignoreAbove := 256
+	titleKeyword.IgnoreAbove = &ignoreAbove
+	titleProperty.Fields = map[string]types.Property{
+		"keyword": titleKeyword,
+		"suggest": types.NewCompletionProperty(),
+	}
+
+	abstractProperty := types.NewTextProperty()
+	abstractProperty.Analyzer = &indexAnalyzer
+	abstractProperty.SearchAnalyzer = &searchAnalyzer
+
+	contentProperty := types.NewTextProperty()
+	contentProperty.Analyzer = &indexAnalyzer
+	contentProperty.SearchAnalyzer = &searchAnalyzer
+
+	return map[string]types.Property{
+		"title":      titleProperty,
+		"abstract":   abstractProperty,
+		"content":    contentProperty,
+		"section":    sectionProperty,
+		"images":     types.NewKeywordProperty(),
+		"videos":     videoProperty,
+		"location":   types.NewGeoPointProperty(),
+		"who_read":   types.NewIntegerNumberProperty(),
+		"who_review": types.NewIntegerNumberProperty(),
+		"category":   types.NewKeywordProperty(),
+		"cover_id":   types.NewIntegerNumberProperty(),
+		"cover_url":  types.NewKeywordProperty(),
+	}
+}
+
+// createDraftIndex 创建草稿索引，正文字段映射与 article_index 保持一致，
+// 额外加上草稿特有的关联文章id、作者id与状态字段
+func (s *ArticleService) createDraftIndex(indexAnalyzer, searchAnalyzer string) error {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	exist, err := s.indexExists(ctx, articleDraftIndex)
+	if err != nil {
+		return fmt.Errorf("检查草稿索引是否存在失败: %w", err)
+	}
+	if exist {
+		if _, err := global.Es.Indices.Delete(articleDraftIndex).Do(ctx); err != nil {
+			return fmt.Errorf("删除已存在的草稿索引失败: %w", err)
+		}
+	}
+
+	properties := s.contentProperties(indexAnalyzer, searchAnalyzer)
+	properties["article_id"] = types.NewKeywordProperty()
+	properties["author_id"] = types.NewIntegerNumberProperty()
+	properties["status"] = types.NewKeywordProperty()
+	properties["created_at"] = types.NewDateProperty()
+	properties["updated_at"] = types.NewDateProperty()
+
+	if _, err := global.Es.Indices.Create(articleDraftIndex).
+		Mappings(&types.TypeMapping{Properties: properties}).
+		Do(ctx); err != nil {
+		return fmt.Errorf("创建草稿索引失败: %w", err)
+	}
+	return nil
+}
+
+// createHistoryIndex 创建历史版本索引，正文字段映射与 article_index 保持一致，
+// 额外加上关联文章id、版本号、作者信息与归档时间
+func (s *ArticleService) createHistoryIndex(indexAnalyzer, searchAnalyzer string) error {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	exist, err := s.indexExists(ctx, articleHistoryIndex)
+	if err != nil {
+		return fmt.Errorf("检查历史版本索引是否存在失败: %w", err)
+	}
+	if exist {
+		if _, err := global.Es.Indices.Delete(articleHistoryIndex).Do(ctx); err != nil {
+			return fmt.Errorf("删除已存在的历史版本索引失败: %w", err)
+		}
+	}
+
+	properties := s.contentProperties(indexAnalyzer, searchAnalyzer)
+	properties["article_id"] = types.NewKeywordProperty()
+	properties["version"] = types.NewLongNumberProperty()
+	properties["user_id"] = types.NewIntegerNumberProperty()
+	properties["user_name"] = types.NewKeywordProperty()
+	properties["archived_at"] = types.NewDateProperty()
+
+	if _, err := global.Es.Indices.Create(articleHistoryIndex).
+		Mappings(&types.TypeMapping{Properties: properties}).
+		Do(ctx); err != nil {
+		return fmt.Errorf("创建历史版本索引失败: %w", err)
+	}
 	return nil
 }
 
@@ -132,7 +394,12 @@ func (s *ArticleService) IndexExists() (bool, error) {
 	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel()
 
-	resp, err := global.Es.Indices.Exists(articleIndex).Do(ctx)
+	return s.indexExists(ctx, articleIndex)
+}
+
+// indexExists 检查指定索引是否存在，供 CreateIndex 在重建草稿/历史索引前复用
+func (s *ArticleService) indexExists(ctx context.Context, index string) (bool, error) {
+	resp, err := global.Es.Indices.Exists(index).Do(ctx)
 	if err != nil {
 		return false, fmt.Errorf("检查索引是否存在失败: %w", err)
 	}
@@ -180,14 +447,18 @@ func (s *ArticleService) CreateArticle(article *Article) error {
 	return s.setCache(article.ID, article)
 }
 
-// GetArticle 获取文章
-func (s *ArticleService) GetArticle(id string) (*Article, error) {
+// GetArticle 获取文章。callerID 为 internalCallerID 表示跳过 WhoRead 校验（内部/后台调用），
+// 未登录的匿名访问请传入真实的 0 值，会按 WhoRead 正常过滤
+func (s *ArticleService) GetArticle(id string, callerID uint) (*Article, error) {
 	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel()
 
 	// 1. 只有已发布的文章才查询缓存
 	article, err := s.getCache(id)
 	if err == nil {
+		if err := s.checkWhoRead(article, callerID); err != nil {
+			return nil, err
+		}
 		// 更新访问计数
 		go s.incrementLookCount(id)
 		return article, nil
@@ -204,6 +475,10 @@ func (s *ArticleService) GetArticle(id string) (*Article, error) {
 		return nil, fmt.Errorf("解析文章数据失败: %w", err)
 	}
 
+	if err := s.checkWhoRead(&result, callerID); err != nil {
+		return nil, err
+	}
+
 	// 3. 只缓存已发布的热门文章（比如阅读量超过100的）
 	if result.LookCount > 100 {
 		if err := s.setCache(id, &result); err != nil {
@@ -214,11 +489,57 @@ func (s *ArticleService) GetArticle(id string) (*Article, error) {
 	return &result, nil
 }
 
+// checkWhoRead 校验调用者是否在文章的可读白名单内，WhoRead 为空表示所有人可读。
+// 只有 internalCallerID 才会跳过校验，匿名调用者（callerID 为 0）一样要按白名单过滤
+func (s *ArticleService) checkWhoRead(article *Article, callerID uint) error {
+	if whoReadAllowed(article.WhoRead, callerID) {
+		return nil
+	}
+	return fmt.Errorf("无权限访问该文章")
+}
+
+// whoReadAllowed 判断 callerID 是否在 whoRead 白名单内，whoRead 为空表示所有人可读。
+// checkWhoRead 和 Suggest 都靠它判断同一份 ACL 规则，避免建议接口漏掉文章可读白名单的过滤
+func whoReadAllowed(whoRead []uint, callerID uint) bool {
+	if callerID == internalCallerID || len(whoRead) == 0 {
+		return true
+	}
+	for _, uid := range whoRead {
+		if uid == callerID {
+			return true
+		}
+	}
+	return false
+}
+
+// whoReadFilter 构造 WhoRead 过滤条件：未设置白名单或调用者在白名单内
+func (s *ArticleService) whoReadFilter(callerID uint) types.Query {
+	whoReadTerm := types.NewTermQuery()
+	whoReadTerm.Value = callerID
+
+	existsQuery := types.NewExistsQuery()
+	existsQuery.Field = "who_read"
+
+	aclShould := types.NewBoolQuery()
+	aclShould.Should = []types.Query{
+		{Term: map[string]types.TermQuery{"who_read": *whoReadTerm}},
+		{Bool: &types.BoolQuery{MustNot: []types.Query{{Exists: existsQuery}}}},
+	}
+	minimumShouldMatch := 1
+	aclShould.MinimumShouldMatch = minimumShouldMatch
+
+	return types.Query{Bool: aclShould}
+}
+
 // UpdateArticle 更新文章
 func (s *ArticleService) UpdateArticle(article *Article) error {
 	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel()
 
+	if err := s.snapshotHistory(ctx, article.ID); err != nil {
+		return fmt.Errorf("归档历史版本失败: %w", err)
+	}
+
 	article.Version++
 	article.UpdatedAt = time.Now()
 
@@ -266,13 +587,19 @@ func (s *ArticleService) DeleteArticles(ids []string) error {
 				return fmt.Errorf("批量删除文章时发生错误")
 			}
 
-			// 删除缓存
+			// 删除缓存与暂存在 Redis 里尚未刷新的计数器增量，避免 CounterFlusher
+			// 之后对一篇已删除的文章反复尝试刷新
 			for _, id := range batch {
 				if err := s.deleteCache(id); err != nil {
 					global.Log.Error("删除缓存失败",
 						zap.String("id", id),
 						zap.Error(err))
 				}
+				if err := s.cache.Del(ctx, counterKeyPrefix+id).Err(); err != nil {
+					global.Log.Error("删除计数器缓存失败",
+						zap.String("id", id),
+						zap.Error(err))
+				}
 			}
 			return nil
 		})
@@ -289,9 +616,15 @@ func (s *ArticleService) SearchArticles(params SearchParams) (*SearchResult, err
 	boolQuery := types.NewBoolQuery()
 
 	if params.PageInfo.Key != "" {
+		fuzziness := params.Fuzziness
+		if fuzziness == "" {
+			fuzziness = "AUTO"
+		}
+
 		multiMatchQuery := types.NewMultiMatchQuery()
 		multiMatchQuery.Query = params.PageInfo.Key
 		multiMatchQuery.Fields = []string{"title^3", "abstract^2", "content"}
+		multiMatchQuery.Fuzziness = fuzziness
 		boolQuery.Must = append(boolQuery.Must, types.Query{MultiMatch: multiMatchQuery})
 	}
 
@@ -301,10 +634,44 @@ func (s *ArticleService) SearchArticles(params SearchParams) (*SearchResult, err
 		boolQuery.Must = append(boolQuery.Must, types.Query{Term: map[string]types.TermQuery{"category": *termQuery}})
 	}
 
+	// 除内部调用外一律按 WhoRead 过滤，匿名访问（CallerID 为 0）同样要被过滤而不是放行
+	if params.CallerID != internalCallerID {
+		boolQuery.Filter = append(boolQuery.Filter, s.whoReadFilter(params.CallerID))
+	}
+
+	if params.NearBy != nil && params.NearBy.RadiusKm > 0 {
+		geoDistanceQuery := types.GeoDistanceQuery{
+			Distance: fmt.Sprintf("%.2fkm", params.NearBy.RadiusKm),
+			GeoDistanceQuery: map[string]types.GeoLocation{
+				"location": types.LatLonGeoLocation{Lat: params.NearBy.Lat, Lon: params.NearBy.Lng},
+			},
+		}
+		boolQuery.Filter = append(boolQuery.Filter, types.Query{GeoDistance: &geoDistanceQuery})
+	}
+
+	highlightField := types.HighlightField{
+		PreTags:  []string{"<em>"},
+		PostTags: []string{"</em>"},
+	}
+
+	categoryAgg := types.NewTermsAggregation()
+	field := "category"
+	categoryAgg.Field = &field
+
 	from := (params.PageInfo.Page - 1) * params.PageInfo.PageSize
 	searchRequest := global.Es.Search().
 		Index(articleIndex).
 		Query(&types.Query{Bool: boolQuery}).
+		Highlight(&types.Highlight{
+			Fields: map[string]types.HighlightField{
+				"title":    highlightField,
+				"abstract": highlightField,
+				"content":  highlightField,
+			},
+		}).
+		Aggregations(map[string]types.Aggregations{
+			"category_facets": {Terms: categoryAgg},
+		}).
 		From(from).
 		Size(params.PageInfo.PageSize)
 
@@ -322,21 +689,111 @@ func (s *ArticleService) SearchArticles(params SearchParams) (*SearchResult, err
 		return nil, fmt.Errorf("搜索文章失败: %w", err)
 	}
 
-	articles := make([]Article, 0)
+	hits := make([]SearchHit, 0, len(resp.Hits.Hits))
 	for _, hit := range resp.Hits.Hits {
 		var article Article
 		if err := json.Unmarshal(hit.Source_, &article); err != nil {
 			global.Log.Error("解析文章数据失败", zap.Error(err))
 			continue
 		}
-		articles = append(articles, article)
+		hits = append(hits, SearchHit{Article: article, Highlights: hit.Highlight})
 	}
+
 	return &SearchResult{
-		Articles: articles,
+		Articles: hits,
 		Total:    resp.Hits.Total.Value,
+		Facets:   parseCategoryFacets(resp.Aggregations),
 	}, nil
 }
 
+// parseCategoryFacets 从分类聚合结果中提取每个分类的文档数
+func parseCategoryFacets(aggregations map[string]types.Aggregate) map[string]int64 {
+	facets := make(map[string]int64)
+
+	agg, ok := aggregations["category_facets"]
+	if !ok {
+		return facets
+	}
+
+	sterms, ok := agg.(*types.StringTermsAggregate)
+	if !ok {
+		return facets
+	}
+
+	buckets, ok := sterms.Buckets.([]types.StringTermsBucket)
+	if !ok {
+		return facets
+	}
+
+	for _, bucket := range buckets {
+		key := fmt.Sprintf("%v", bucket.Key)
+		facets[key] = bucket.DocCount
+	}
+
+	return facets
+}
+
+// Suggest 根据标题前缀返回搜索建议，结果短期缓存在 Redis 中。callerID 不在文章
+// WhoRead 白名单内的候选会被过滤掉，避免把受限文章的标题泄露给猜中前缀的调用者；
+// 缓存 key 按 callerID 区分，不同权限的调用者不会复用彼此的建议结果
+func (s *ArticleService) Suggest(prefix string, callerID uint) ([]string, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	cacheKey := fmt.Sprintf("article:suggest:%d:%s", callerID, prefix)
+	if cached, err := s.cache.Get(ctx, cacheKey).Bytes(); err == nil {
+		var suggestions []string
+		if err := json.Unmarshal(cached, &suggestions); err == nil {
+			return suggestions, nil
+		}
+	}
+
+	completionSuggester := types.NewCompletionSuggester()
+	completionSuggester.Field = "title.suggest"
+
+	resp, err := global.Es.Search().
+		Index(articleIndex).
+		Suggest(&types.Suggester{
+			Suggesters: map[string]types.FieldSuggester{
+				"title_suggest": {Completion: completionSuggester, Prefix: prefix},
+			},
+		}).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取搜索建议失败: %w", err)
+	}
+
+	suggestions := make([]string, 0)
+	for _, suggest := range resp.Suggest["title_suggest"] {
+		if suggest.CompletionSuggest == nil {
+			continue
+		}
+		for _, option := range suggest.CompletionSuggest.Options {
+			var doc struct {
+				WhoRead []uint `json:"who_read"`
+			}
+			if len(option.Source_) > 0 {
+				if err := json.Unmarshal(option.Source_, &doc); err != nil {
+					global.Log.Warn("解析搜索建议候选文档失败", zap.Error(err))
+					continue
+				}
+			}
+			if !whoReadAllowed(doc.WhoRead, callerID) {
+				continue
+			}
+			suggestions = append(suggestions, option.Text)
+		}
+	}
+
+	if data, err := json.Marshal(suggestions); err == nil {
+		if err := s.cache.Set(ctx, cacheKey, data, suggestCacheTTL).Err(); err != nil {
+			global.Log.Warn("缓存搜索建议失败", zap.Error(err))
+		}
+	}
+
+	return suggestions, nil
+}
+
 // 缓存相关方法
 func (s *ArticleService) getCacheKey(id string) string {
 	return fmt.Sprintf("article:%s", id)
@@ -384,15 +841,32 @@ func (s *ArticleService) deleteCache(id string) error {
 // SearchParams 搜索参数
 type SearchParams struct {
 	PageInfo
-	Category  string `json:"category" form:"category"`
-	SortField string `json:"sort_field" form:"sort_field"`
-	SortOrder string `json:"sort_order" form:"sort_order"`
+	Category  string  `json:"category" form:"category"`
+	SortField string  `json:"sort_field" form:"sort_field"`
+	SortOrder string  `json:"sort_order" form:"sort_order"`
+	NearBy    *NearBy `json:"near_by" form:"near_by"`     // 按地理位置过滤
+	CallerID  uint    `json:"caller_id" form:"-"`         // 当前登录用户id，用于 WhoRead 过滤，由调用方注入
+	Fuzziness string  `json:"fuzziness" form:"fuzziness"` // 模糊匹配容错度，默认 AUTO
+}
+
+// NearBy 地理位置范围过滤条件
+type NearBy struct {
+	Lat      float64 `json:"lat" form:"lat"`
+	Lng      float64 `json:"lng" form:"lng"`
+	RadiusKm float64 `json:"radius_km" form:"radius_km"`
 }
 
 // SearchResult 搜索结果
 type SearchResult struct {
-	Articles []Article
+	Articles []SearchHit
 	Total    int64
+	Facets   map[string]int64 `json:"facets,omitempty"` // 分类聚合计数
+}
+
+// SearchHit 单条搜索命中结果，附带高亮片段
+type SearchHit struct {
+	Article
+	Highlights map[string][]string `json:"highlights,omitempty"`
 }
 
 // ArticleExists 检查文章是否存在
@@ -417,30 +891,331 @@ func (s *ArticleService) ArticleExists(id string) (bool, error) {
 
 // incrementLookCount 增加文章访问计数
 func (s *ArticleService) incrementLookCount(id string) error {
+	return s.incrementCounter(id, counterFieldLookCount)
+}
+
+// IncrementDiggCount 增加文章点赞计数
+func (s *ArticleService) IncrementDiggCount(id string) error {
+	return s.incrementCounter(id, counterFieldDiggCount)
+}
+
+// IncrementCommentCount 增加文章评论计数
+func (s *ArticleService) IncrementCommentCount(id string) error {
+	return s.incrementCounter(id, counterFieldCommentCount)
+}
+
+// IncrementCollectsCount 增加文章收藏计数
+func (s *ArticleService) IncrementCollectsCount(id string) error {
+	return s.incrementCounter(id, counterFieldCollectsCount)
+}
+
+// incrementCounter 原子递增 Redis 中的计数器，不直接写 ES，
+// 避免每次访问都触发一次 ES 写入并和乐观锁的 Version 字段冲突。
+// 真正落库由 CounterFlusher 周期性批量刷新完成。
+func (s *ArticleService) incrementCounter(id, field string) error {
+	key := counterKeyPrefix + id
+	if err := s.cache.HIncrBy(s.ctx, key, field, 1).Err(); err != nil {
+		return fmt.Errorf("递增计数器失败: %w", err)
+	}
+
+	// 计数的权威来源是上面的 Redis HIncrBy（由 CounterFlusher 定期刷新到 ES）。
+	// 这里不再乐观地读-改-写整篇缓存文章，那样在并发递增下会互相覆盖、丢更新；
+	// 直接失效缓存，下次读取未命中时会从 ES 重新加载，自然带上最新计数
+	if err := s.deleteCache(id); err != nil {
+		global.Log.Warn("失效文章缓存失败", zap.Error(err))
+	}
+
+	return nil
+}
+
+// Close 停止后台计数器刷新协程，应在服务关闭时调用
+func (s *ArticleService) Close() {
+	if s.flusher != nil {
+		s.flusher.Stop()
+	}
+}
+
+// historyDocID 历史版本文档id，按 (article_id, version) 唯一确定
+func (s *ArticleService) historyDocID(articleID string, version int64) string {
+	return fmt.Sprintf("%s_%d", articleID, version)
+}
+
+// snapshotHistory 在覆盖文章正文前，把当前已持久化的版本归档到历史索引
+func (s *ArticleService) snapshotHistory(ctx context.Context, id string) error {
+	resp, err := global.Es.Get(articleIndex, id).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("获取当前文章失败: %w", err)
+	}
+
+	var current Article
+	if err := json.Unmarshal(resp.Source_, &current); err != nil {
+		return fmt.Errorf("解析当前文章数据失败: %w", err)
+	}
+
+	history := ArticleHistory{
+		ArticleID:  current.ID,
+		Version:    current.Version,
+		Title:      current.Title,
+		Abstract:   current.Abstract,
+		Content:    current.Content,
+		Section:    current.Section,
+		Images:     current.Images,
+		Videos:     current.Videos,
+		Location:   current.Location,
+		WhoRead:    current.WhoRead,
+		WhoReview:  current.WhoReview,
+		Category:   current.Category,
+		CoverID:    current.CoverID,
+		CoverURL:   current.CoverURL,
+		UserID:     current.UserID,
+		UserName:   current.UserName,
+		ArchivedAt: time.Now(),
+	}
+
+	_, err = global.Es.Index(articleHistoryIndex).
+		Id(s.historyDocID(current.ID, current.Version)).
+		Document(history).
+		Refresh(refresh.True).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("写入历史版本失败: %w", err)
+	}
+	return nil
+}
+
+// CreateDraft 创建草稿
+func (s *ArticleService) CreateDraft(draft *ArticleDraft) error {
 	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel()
 
-	// 使用 ES 的 update API 来原子递增 look_count
-	script := types.InlineScript{
-		Source: "ctx._source.look_count++",
+	draft.CreatedAt = time.Now()
+	draft.UpdatedAt = time.Now()
+	draft.Status = DraftStatusDraft
+
+	_, err := global.Es.Index(articleDraftIndex).
+		Id(draft.ID).
+		Document(draft).
+		Refresh(refresh.True).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("创建草稿失败: %w", err)
 	}
-	_, err := global.Es.Update(articleIndex, id).
-		Script(&script).
+	return nil
+}
+
+// UpdateDraft 更新草稿内容
+func (s *ArticleService) UpdateDraft(draft *ArticleDraft) error {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	draft.UpdatedAt = time.Now()
+
+	_, err := global.Es.Update(articleDraftIndex, draft.ID).
+		Doc(draft).
 		Refresh(refresh.True).
 		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("更新草稿失败: %w", err)
+	}
+	return nil
+}
+
+// ListDrafts 列出某作者的草稿，按更新时间倒序
+func (s *ArticleService) ListDrafts(authorID uint) ([]ArticleDraft, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	termQuery := types.NewTermQuery()
+	termQuery.Value = authorID
+
+	resp, err := global.Es.Search().
+		Index(articleDraftIndex).
+		Query(&types.Query{Term: map[string]types.TermQuery{"author_id": *termQuery}}).
+		Sort([]string{"updated_at:desc"}).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询草稿列表失败: %w", err)
+	}
+
+	drafts := make([]ArticleDraft, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var draft ArticleDraft
+		if err := json.Unmarshal(hit.Source_, &draft); err != nil {
+			global.Log.Error("解析草稿数据失败", zap.Error(err))
+			continue
+		}
+		drafts = append(drafts, draft)
+	}
+	return drafts, nil
+}
+
+// PublishDraft 将草稿内容发布为正式文章：首次发布即创建文章，
+// 后续发布先把旧版本归档到历史索引，再覆盖正文并清空缓存。
+// 各步依次写入 ES，整体不是原子的：草稿状态更新失败时只记录日志，文章已经发布
+func (s *ArticleService) PublishDraft(draftID string) (*Article, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
 
+	resp, err := global.Es.Get(articleDraftIndex, draftID).Do(ctx)
 	if err != nil {
-		return fmt.Errorf("更新访问计数失败: %w", err)
+		return nil, fmt.Errorf("获取草稿失败: %w", err)
 	}
 
-	// 更新缓存中的访问计数
-	article, err := s.getCache(id)
-	if err == nil {
-		article.LookCount++
-		if err := s.setCache(id, article); err != nil {
-			global.Log.Warn("更新缓存中的访问计数失败", zap.Error(err))
+	var draft ArticleDraft
+	if err := json.Unmarshal(resp.Source_, &draft); err != nil {
+		return nil, fmt.Errorf("解析草稿数据失败: %w", err)
+	}
+
+	article := &Article{
+		ID:       draft.ArticleID,
+		Title:    draft.Title,
+		Abstract: draft.Abstract,
+		Content:  draft.Content,
+		Category: draft.Category,
+		CoverID:  draft.CoverID,
+		CoverURL: draft.CoverURL,
+		UserID:   draft.AuthorID,
+	}
+
+	if article.ID == "" {
+		// 草稿首次发布：直接创建新文章
+		article.ID = draft.ID
+		if err := s.CreateArticle(article); err != nil {
+			return nil, fmt.Errorf("发布草稿失败: %w", err)
+		}
+		draft.ArticleID = article.ID
+	} else {
+		// 已发布过的文章：保留统计字段，覆盖正文触发历史归档。
+		// 草稿只携带标题/正文等窄字段，ES 的 Doc 更新是覆盖式的，
+		// 必须把草稿未涉及的 Section/Images/Videos/Location/WhoRead/WhoReview/UserName/CreatedAt 从原文章合并回去，
+		// 否则发布会把这些字段静默清空
+		existing, err := s.GetArticle(article.ID, internalCallerID)
+		if err != nil {
+			return nil, fmt.Errorf("获取原文章失败: %w", err)
+		}
+		article.Version = existing.Version
+		article.CreatedAt = existing.CreatedAt
+		article.UserName = existing.UserName
+		article.LookCount = existing.LookCount
+		article.CommentCount = existing.CommentCount
+		article.DiggCount = existing.DiggCount
+		article.CollectsCount = existing.CollectsCount
+		article.Section = existing.Section
+		article.Images = existing.Images
+		article.Videos = existing.Videos
+		article.Location = existing.Location
+		article.WhoRead = existing.WhoRead
+		article.WhoReview = existing.WhoReview
+		if err := s.UpdateArticle(article); err != nil {
+			return nil, fmt.Errorf("发布草稿失败: %w", err)
+		}
+	}
+
+	draft.Status = DraftStatusPublished
+	draft.UpdatedAt = time.Now()
+	if _, err := global.Es.Update(articleDraftIndex, draft.ID).
+		Doc(draft).
+		Refresh(refresh.True).
+		Do(ctx); err != nil {
+		global.Log.Warn("更新草稿状态失败", zap.Error(err))
+	}
+
+	if err := s.deleteCache(article.ID); err != nil {
+		global.Log.Warn("清除文章缓存失败", zap.Error(err))
+	}
+
+	return article, nil
+}
+
+// GetArticleHistory 获取指定版本的历史快照
+func (s *ArticleService) GetArticleHistory(id string, version int64) (*ArticleHistory, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	resp, err := global.Es.Get(articleHistoryIndex, s.historyDocID(id, version)).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史版本失败: %w", err)
+	}
+
+	var history ArticleHistory
+	if err := json.Unmarshal(resp.Source_, &history); err != nil {
+		return nil, fmt.Errorf("解析历史版本数据失败: %w", err)
+	}
+	return &history, nil
+}
+
+// ListArticleHistory 列出文章的全部历史版本，按版本号倒序
+func (s *ArticleService) ListArticleHistory(id string) ([]ArticleHistory, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	termQuery := types.NewTermQuery()
+	termQuery.Value = id
+
+	resp, err := global.Es.Search().
+		Index(articleHistoryIndex).
+		Query(&types.Query{Term: map[string]types.TermQuery{"article_id": *termQuery}}).
+		Sort([]string{"version:desc"}).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询历史版本列表失败: %w", err)
+	}
+
+	histories := make([]ArticleHistory, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var history ArticleHistory
+		if err := json.Unmarshal(hit.Source_, &history); err != nil {
+			global.Log.Error("解析历史版本数据失败", zap.Error(err))
+			continue
 		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
+
+// RestoreArticleHistory 将文章内容回滚到指定历史版本，保留当前统计字段与版本号递增语义
+func (s *ArticleService) RestoreArticleHistory(id string, version int64) error {
+	history, err := s.GetArticleHistory(id, version)
+	if err != nil {
+		return fmt.Errorf("恢复历史版本失败: %w", err)
+	}
+
+	current, err := s.GetArticle(id, internalCallerID)
+	if err != nil {
+		return fmt.Errorf("恢复历史版本失败: %w", err)
+	}
+
+	// 正文和媒体/ACL字段都以历史快照为准，统计字段与版本号沿用当前文章
+	article := &Article{
+		ID:            id,
+		Title:         history.Title,
+		Abstract:      history.Abstract,
+		Content:       history.Content,
+		Section:       history.Section,
+		Images:        history.Images,
+		Videos:        history.Videos,
+		Location:      history.Location,
+		WhoRead:       history.WhoRead,
+		WhoReview:     history.WhoReview,
+		Category:      history.Category,
+		CoverID:       history.CoverID,
+		CoverURL:      history.CoverURL,
+		UserID:        history.UserID,
+		UserName:      history.UserName,
+		Version:       current.Version,
+		LookCount:     current.LookCount,
+		CommentCount:  current.CommentCount,
+		DiggCount:     current.DiggCount,
+		CollectsCount: current.CollectsCount,
+	}
+
+	if err := s.UpdateArticle(article); err != nil {
+		return fmt.Errorf("恢复历史版本失败: %w", err)
+	}
+
+	if err := s.deleteCache(id); err != nil {
+		global.Log.Warn("清除文章缓存失败", zap.Error(err))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"blog/global"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"go.uber.org/zap"
+)
+
+const articleSecurityIndex = "article_security_index"
+
+// 审核状态
+const (
+	SecurityStatusPending  = "pending"  // 待审核
+	SecurityStatusPassed   = "passed"   // 审核通过
+	SecurityStatusRejected = "rejected" // 审核拒绝
+)
+
+const offenseCounterTTL = time.Hour * 24 * 30
+
+// ArticleSecurity 命中敏感词、落入待审核队列的文章
+type ArticleSecurity struct {
+	Article
+	Status         string    `json:"status"` // pending | passed | rejected
+	SensitiveWords []string  `json:"sensitive_words"`
+	IsUpdate       bool      `json:"is_update,omitempty"` // true 表示这是已发布文章的编辑，审核通过后要 Update 而不是 Create
+	Reason         string    `json:"reason,omitempty"`
+	AuditedBy      uint      `json:"audited_by,omitempty"`
+	AuditedAt      time.Time `json:"audited_at,omitempty"`
+}
+
+// ArticleSecurityService 文章审核队列服务
+type ArticleSecurityService struct {
+	ctx     context.Context
+	article *ArticleService
+}
+
+// NewArticleSecurityService 创建审核队列服务实例。article 复用调用方已持有的 ArticleService，
+// 而不是另起一个——ArticleService 内部会启动 CounterFlusher 后台协程，重复创建会导致同一批
+// 计数器 key 和文章索引被两个协程并发扫描/刷新
+func NewArticleSecurityService(article *ArticleService) *ArticleSecurityService {
+	return &ArticleSecurityService{
+		ctx:     context.Background(),
+		article: article,
+	}
+}
+
+// CreatePending 将命中敏感词的文章写入待审核队列，而不是直接写入 article_index。
+// isUpdate 标记这是对已发布文章的编辑而不是新建，审核通过后据此决定发布时走
+// CreateArticle 还是 UpdateArticle
+func (s *ArticleSecurityService) CreatePending(article *Article, sensitiveWords []string, isUpdate bool) error {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	article.CreatedAt = time.Now()
+	article.UpdatedAt = time.Now()
+	article.Version = 1
+
+	security := &ArticleSecurity{
+		Article:        *article,
+		Status:         SecurityStatusPending,
+		SensitiveWords: sensitiveWords,
+		IsUpdate:       isUpdate,
+	}
+
+	_, err := global.Es.Index(articleSecurityIndex).
+		Id(security.ID).
+		Document(security).
+		Refresh(refresh.True).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("创建待审核文章失败: %w", err)
+	}
+
+	return nil
+}
+
+// SecuritySearch 查询待审核队列，复用 SearchParams 的分页参数；固定按创建时间倒序，不使用 SortField/SortOrder
+func (s *ArticleSecurityService) SecuritySearch(params SearchParams) ([]ArticleSecurity, int64, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	boolQuery := types.NewBoolQuery()
+	statusTerm := types.NewTermQuery()
+	statusTerm.Value = SecurityStatusPending
+	boolQuery.Must = append(boolQuery.Must, types.Query{Term: map[string]types.TermQuery{"status": *statusTerm}})
+
+	from := (params.PageInfo.Page - 1) * params.PageInfo.PageSize
+	resp, err := global.Es.Search().
+		Index(articleSecurityIndex).
+		Query(&types.Query{Bool: boolQuery}).
+		From(from).
+		Size(params.PageInfo.PageSize).
+		Sort([]string{"created_at:desc"}).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("查询待审核队列失败: %w", err)
+	}
+
+	items := make([]ArticleSecurity, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var item ArticleSecurity
+		if err := json.Unmarshal(hit.Source_, &item); err != nil {
+			global.Log.Error("解析待审核文章失败", zap.Error(err))
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, resp.Hits.Total.Value, nil
+}
+
+// SecurityGet 获取一条待审核记录
+func (s *ArticleSecurityService) SecurityGet(id string) (*ArticleSecurity, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	resp, err := global.Es.Get(articleSecurityIndex, id).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取待审核文章失败: %w", err)
+	}
+
+	var item ArticleSecurity
+	if err := json.Unmarshal(resp.Source_, &item); err != nil {
+		return nil, fmt.Errorf("解析待审核文章失败: %w", err)
+	}
+	return &item, nil
+}
+
+// SecurityAudit 审核一条待审核记录：通过则经由 CreateArticle 正式发布（IsUpdate 为 true 时经由 UpdateArticle），
+// 拒绝则软删除（标记 rejected 并记录原因），同时累计提交者的违规次数，
+// 达到阈值后冻结该用户
+func (s *ArticleSecurityService) SecurityAudit(id string, pass bool, reason string, auditorID uint) error {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	item, err := s.SecurityGet(id)
+	if err != nil {
+		return fmt.Errorf("审核失败: %w", err)
+	}
+
+	item.Reason = reason
+	item.AuditedBy = auditorID
+	item.AuditedAt = time.Now()
+
+	if pass {
+		item.Status = SecurityStatusPassed
+		published := item.Article
+		if item.IsUpdate {
+			if err := s.article.UpdateArticle(&published); err != nil {
+				return fmt.Errorf("发布审核通过的文章失败: %w", err)
+			}
+		} else if err := s.article.CreateArticle(&published); err != nil {
+			return fmt.Errorf("发布审核通过的文章失败: %w", err)
+		}
+	} else {
+		item.Status = SecurityStatusRejected
+		if err := s.recordOffense(ctx, item.UserID); err != nil {
+			global.Log.Warn("记录违规次数失败", zap.Uint("user_id", item.UserID), zap.Error(err))
+		}
+	}
+
+	_, err = global.Es.Update(articleSecurityIndex, id).
+		Doc(item).
+		Refresh(refresh.True).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("更新审核记录失败: %w", err)
+	}
+
+	return nil
+}
+
+// recordOffense 累计用户违规次数，超过配置阈值后冻结该用户
+func (s *ArticleSecurityService) recordOffense(ctx context.Context, userID uint) error {
+	threshold := global.Config.Sensitive.FreezeThreshold
+	if threshold <= 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf("article:security:offense:%d", userID)
+	count, err := global.Redis.Incr(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("递增违规计数失败: %w", err)
+	}
+	if count == 1 {
+		global.Redis.Expire(ctx, key, offenseCounterTTL)
+	}
+
+	if int(count) >= threshold {
+		if err := global.DB.Model(&UserModel{}).Where("id = ?", userID).Update("status", "frozen").Error; err != nil {
+			return fmt.Errorf("冻结用户失败: %w", err)
+		}
+		global.Log.Warn("用户违规次数超过阈值，已冻结", zap.Uint("user_id", userID), zap.Int64("count", count))
+	}
+
+	return nil
+}
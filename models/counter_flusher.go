@@ -0,0 +1,228 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"blog/global"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"go.uber.org/zap"
+)
+
+// counterScanBatch 每轮 SCAN 拉取的 key 数量
+const counterScanBatch = 100
+
+// popCountersScript 以原子方式读取并清空一个文章的计数器哈希。
+// HGETALL 和 DEL 在同一条脚本内执行，期间不会有其它客户端的 HINCRBY 插入进来，
+// 因此不会丢失在刷新过程中产生的新增量。
+const popCountersScript = `
+local vals = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return vals
+`
+
+// CounterFlusher 周期性地把 Redis 中暂存的文章计数器增量批量刷新到 ES
+type CounterFlusher struct {
+	article  *ArticleService
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewCounterFlusher 创建计数器刷新器
+func NewCounterFlusher(article *ArticleService, interval time.Duration) *CounterFlusher {
+	return &CounterFlusher{
+		article:  article,
+		interval: interval,
+	}
+}
+
+// Start 启动后台刷新协程
+func (f *CounterFlusher) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	f.cancel = cancel
+	f.done = make(chan struct{})
+
+	go f.run(ctx)
+}
+
+// Stop 停止后台刷新协程，阻塞直到协程退出
+func (f *CounterFlusher) Stop() {
+	if f.cancel == nil {
+		return
+	}
+	f.cancel()
+	<-f.done
+}
+
+func (f *CounterFlusher) run(ctx context.Context) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.flush(ctx)
+		}
+	}
+}
+
+// flush 扫描所有脏计数器 key，批量刷新到 ES 后清空；刷新失败的增量会回填 Redis 等待下次重试，
+// 而不是随着本轮 pop 一起丢弃
+func (f *CounterFlusher) flush(ctx context.Context) {
+	dirty, err := f.collectDirtyCounters(ctx)
+	if err != nil {
+		global.Log.Error("扫描计数器失败", zap.Error(err))
+		return
+	}
+
+	if len(dirty) == 0 {
+		return
+	}
+
+	failed, err := f.bulkUpdate(ctx, dirty)
+	if err != nil {
+		global.Log.Error("批量刷新计数器到ES失败", zap.Error(err))
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	requeue := make(map[string]map[string]int64, len(failed))
+	for id := range failed {
+		requeue[id] = dirty[id]
+	}
+	f.requeueCounters(ctx, requeue)
+}
+
+// collectDirtyCounters 扫描所有计数器 key，原子地取出增量并清空
+func (f *CounterFlusher) collectDirtyCounters(ctx context.Context) (map[string]map[string]int64, error) {
+	dirty := make(map[string]map[string]int64)
+
+	var cursor uint64
+	for {
+		keys, next, err := global.Redis.Scan(ctx, cursor, counterKeyPrefix+"*", counterScanBatch).Result()
+		if err != nil {
+			return nil, fmt.Errorf("扫描计数器key失败: %w", err)
+		}
+
+		for _, key := range keys {
+			id := strings.TrimPrefix(key, counterKeyPrefix)
+
+			deltas, err := f.popCounters(ctx, key)
+			if err != nil {
+				global.Log.Error("读取并清空计数器失败", zap.String("key", key), zap.Error(err))
+				continue
+			}
+			if len(deltas) == 0 {
+				continue
+			}
+			dirty[id] = deltas
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return dirty, nil
+}
+
+// popCounters 原子地读取并清空单篇文章的计数器哈希
+func (f *CounterFlusher) popCounters(ctx context.Context, key string) (map[string]int64, error) {
+	raw, err := global.Redis.Eval(ctx, popCountersScript, []string{key}).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("执行计数器清空脚本失败: %w", err)
+	}
+
+	deltas := make(map[string]int64, len(raw)/2)
+	for i := 0; i+1 < len(raw); i += 2 {
+		var delta int64
+		if _, err := fmt.Sscanf(raw[i+1], "%d", &delta); err != nil || delta == 0 {
+			continue
+		}
+		deltas[raw[i]] = delta
+	}
+
+	return deltas, nil
+}
+
+// bulkUpdate 把每篇文章的计数器增量合并为一条 painless 脚本，通过 _bulk 一次性写入 ES。
+// 返回未成功写入的文章id集合，调用方需要把这些id对应的增量回填到 Redis，避免（例如并发删除
+// 导致单条 update 404 这种）部分失败时把已经从 Redis 弹出的增量凭空丢掉
+func (f *CounterFlusher) bulkUpdate(ctx context.Context, dirty map[string]map[string]int64) (map[string]struct{}, error) {
+	bulkRequest := global.Es.Bulk().Index(articleIndex)
+
+	ids := make([]string, 0, len(dirty))
+	for id, deltas := range dirty {
+		var source strings.Builder
+		params := make(map[string]interface{}, len(deltas))
+		for field, delta := range deltas {
+			fmt.Fprintf(&source, "ctx._source.%s = (ctx._source.%s == null ? 0 : ctx._source.%s) + params.%s; ",
+				field, field, field, field)
+			params[field] = delta
+		}
+		if source.Len() == 0 {
+			continue
+		}
+
+		docID := id
+		script := types.InlineScript{Source: source.String(), Params: params}
+		bulkRequest.UpdateOp(types.UpdateOperation{Id_: &docID}, types.UpdateAction{Script: &script})
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	resp, err := bulkRequest.Refresh(refresh.True).Do(ctx)
+	if err != nil {
+		// 请求本身失败（网络抖动等），整批都要回填重试
+		failed := make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			failed[id] = struct{}{}
+		}
+		return failed, fmt.Errorf("批量刷新计数器失败: %w", err)
+	}
+	if !resp.Errors {
+		return nil, nil
+	}
+
+	// 部分失败：只回填真正失败的文章（比如计数器刷新前文章已被并发删除），
+	// 已经成功写入的文章不能回填，否则下次刷新会把增量重复累加一遍
+	failed := make(map[string]struct{})
+	for i, item := range resp.Items {
+		result, ok := item["update"]
+		if !ok || result.Error == nil {
+			continue
+		}
+		id := ids[i]
+		failed[id] = struct{}{}
+		global.Log.Warn("文章计数器刷新失败", zap.String("id", id), zap.Any("error", result.Error))
+	}
+	return failed, fmt.Errorf("批量刷新计数器时发生部分错误")
+}
+
+// requeueCounters 把未成功写入 ES 的计数器增量合并回 Redis，等待下一轮刷新重试
+func (f *CounterFlusher) requeueCounters(ctx context.Context, dirty map[string]map[string]int64) {
+	for id, deltas := range dirty {
+		key := counterKeyPrefix + id
+		for field, delta := range deltas {
+			if err := global.Redis.HIncrBy(ctx, key, field, delta).Err(); err != nil {
+				global.Log.Error("计数器增量回填失败，数据可能丢失",
+					zap.String("id", id), zap.String("field", field), zap.Int64("delta", delta), zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"blog/global"
+)
+
+// TestCounterFlusher_NoLossUnderConcurrentIncrements 并发调用 IncrementDiggCount 模拟真实流量，
+// 经过若干个短周期的 flush 之后，断言 ES 里落盘的计数与实际递增次数完全一致，不多不少。
+// 依赖本地/CI 环境中可用的 Elasticsearch 与 Redis，未配置时自动跳过
+func TestCounterFlusher_NoLossUnderConcurrentIncrements(t *testing.T) {
+	if global.Es == nil || global.Redis == nil {
+		t.Skip("未配置 Elasticsearch/Redis，跳过集成测试")
+	}
+
+	s := NewArticleService()
+	// 关掉 NewArticleService 自带的默认周期 flusher，换成本测试里更短周期的实例，
+	// 避免两个 flusher 同时扫描、重复刷新同一批计数器 key
+	s.Close()
+
+	article := &Article{ID: "counter-flusher-test-article", Title: "计数器测试文章"}
+	if err := s.CreateArticle(article); err != nil {
+		t.Fatalf("创建测试文章失败: %v", err)
+	}
+	defer s.DeleteArticles([]string{article.ID})
+
+	const goroutines = 20
+	const incrementsPerGoroutine = 50
+	const wantTotal = goroutines * incrementsPerGoroutine
+
+	flusher := NewCounterFlusher(s, 50*time.Millisecond)
+	flusher.Start(context.Background())
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < incrementsPerGoroutine; j++ {
+				if err := s.IncrementDiggCount(article.ID); err != nil {
+					t.Errorf("递增计数失败: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 多等几个 flush 周期，确保所有增量都已经从 Redis 刷新到 ES
+	time.Sleep(300 * time.Millisecond)
+	flusher.Stop()
+
+	// incrementCounter 在每次递增时都会失效对象缓存，这里再次清缓存只是保险，
+	// 确保接下来的 GetArticle 落到 ES，断言的是 CounterFlusher 真正刷新到 ES 里的计数
+	if err := s.deleteCache(article.ID); err != nil {
+		t.Fatalf("清除文章缓存失败: %v", err)
+	}
+
+	got, err := s.GetArticle(article.ID, internalCallerID)
+	if err != nil {
+		t.Fatalf("获取文章失败: %v", err)
+	}
+	if int(got.DiggCount) != wantTotal {
+		t.Errorf("点赞数计数丢失: got %d, want %d", got.DiggCount, wantTotal)
+	}
+}
@@ -0,0 +1,232 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"blog/global"
+	"blog/storage"
+
+	"github.com/disintegration/imaging"
+	"go.uber.org/zap"
+)
+
+// ImageModel 图片/视频资源记录，Hash 为文件内容的 SHA-256，用于内容寻址去重
+type ImageModel struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Hash      string    `json:"hash" gorm:"uniqueIndex;size:64"`
+	FileName  string    `json:"file_name"` // 存储后端内的相对路径（key）
+	Url       string    `json:"url"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+}
+
+// UploadResponse 上传接口返回结构
+type UploadResponse struct {
+	IsSuccess  bool           `json:"is_success"`
+	Msg        string         `json:"msg,omitempty"`
+	FileName   string         `json:"-"` // 存储后端内的相对路径（key），不写入响应
+	Url        string         `json:"url,omitempty"`
+	Thumbnails map[int]string `json:"thumbnails,omitempty"`
+	Hash       string         `json:"hash,omitempty"`
+	Width      int            `json:"width,omitempty"`
+	Height     int            `json:"height,omitempty"`
+	Size       int64          `json:"size,omitempty"`
+}
+
+// Upload 内容寻址的上传流水线：边读边计算 SHA-256，命中已有记录直接去重复用；
+// 否则嗅探 MIME、校验大小上限，写入可插拔的存储后端，并为图片生成多档缩略图。
+func (m *ImageModel) Upload(file *multipart.FileHeader) UploadResponse {
+	src, err := file.Open()
+	if err != nil {
+		return UploadResponse{IsSuccess: false, Msg: "打开上传文件失败"}
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return UploadResponse{IsSuccess: false, Msg: "创建临时文件失败"}
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), src)
+	if err != nil {
+		return UploadResponse{IsSuccess: false, Msg: "读取上传文件失败"}
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	// 1. 去重：已存在相同内容的文件直接复用
+	var existing ImageModel
+	if err := global.DB.Where("hash = ?", hash).First(&existing).Error; err == nil {
+		return UploadResponse{
+			IsSuccess: true,
+			FileName:  existing.FileName,
+			Url:       existing.Url,
+			Hash:      existing.Hash,
+			Width:     existing.Width,
+			Height:    existing.Height,
+			Size:      existing.Size,
+		}
+	}
+
+	// 2. 嗅探 MIME 类型，只接受图片/视频
+	header := make([]byte, 512)
+	n, err := tmp.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return UploadResponse{IsSuccess: false, Msg: "读取文件内容失败"}
+	}
+	mimeType := http.DetectContentType(header[:n])
+	if !strings.HasPrefix(mimeType, "image/") && !strings.HasPrefix(mimeType, "video/") {
+		return UploadResponse{IsSuccess: false, Msg: "不支持的文件类型"}
+	}
+
+	// 3. 按 MIME 类型校验大小上限
+	if maxSize, ok := global.Config.Upload.MaxSize[mimeType]; ok && maxSize > 0 && size > maxSize {
+		return UploadResponse{IsSuccess: false, Msg: "文件大小超出限制"}
+	}
+
+	key := fmt.Sprintf("%s/%s%s", time.Now().Format("20060102"), hash, filepath.Ext(file.Filename))
+	backend := storage.New(global.Config.Upload.Driver)
+	ctx := context.Background()
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return UploadResponse{IsSuccess: false, Msg: "重置文件指针失败"}
+	}
+	url, err := backend.Save(ctx, key, tmp)
+	if err != nil {
+		global.Log.Error("保存上传文件失败", zap.String("filename", file.Filename), zap.Error(err))
+		return UploadResponse{IsSuccess: false, Msg: "保存文件失败"}
+	}
+
+	width, height := 0, 0
+	var thumbnails map[int]string
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		if _, err := tmp.Seek(0, io.SeekStart); err == nil {
+			width, height, thumbnails = generateThumbnails(ctx, backend, tmp, key, hash)
+		}
+	case strings.HasPrefix(mimeType, "video/"):
+		if w, h, err := probeVideoDimensions(tmp.Name()); err != nil {
+			global.Log.Warn("探测视频尺寸失败", zap.String("filename", file.Filename), zap.Error(err))
+		} else {
+			width, height = w, h
+		}
+	}
+
+	record := ImageModel{
+		Hash:     hash,
+		FileName: key,
+		Url:      url,
+		MimeType: mimeType,
+		Size:     size,
+		Width:    width,
+		Height:   height,
+	}
+	if err := global.DB.Create(&record).Error; err != nil {
+		global.Log.Error("保存图片记录失败", zap.Error(err))
+		return UploadResponse{IsSuccess: false, Msg: "保存图片记录失败"}
+	}
+
+	return UploadResponse{
+		IsSuccess:  true,
+		FileName:   key,
+		Url:        url,
+		Thumbnails: thumbnails,
+		Hash:       hash,
+		Width:      width,
+		Height:     height,
+		Size:       size,
+	}
+}
+
+// thumbnailFormat 缩略图编码格式。disintegration/imaging 不支持 WebP 编码（只有
+// golang.org/x/image/webp 的解码器，没有纯 Go 的编码器），所以无论 ThumbnailFormat
+// 配置成什么，目前都只能落盘成 JPEG；保留配置项是为了将来接入支持 WebP 编码的库时
+// 不用改调用方
+func thumbnailFormat() (imaging.Format, string) {
+	if format := global.Config.Upload.ThumbnailFormat; format != "" && format != "jpeg" {
+		global.Log.Warn("缩略图格式暂不支持，已回退为jpeg", zap.String("configured", format))
+	}
+	return imaging.JPEG, "jpg"
+}
+
+// generateThumbnails 解码原图尺寸，并按配置的宽度生成缩略图上传到同一个存储后端
+func generateThumbnails(ctx context.Context, backend storage.Storage, r io.ReadSeeker, key, hash string) (int, int, map[int]string) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		global.Log.Warn("解码图片失败，跳过缩略图生成", zap.Error(err))
+		return 0, 0, nil
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	widths := global.Config.Upload.ThumbnailWidths
+	if len(widths) == 0 {
+		return width, height, nil
+	}
+
+	thumbnails := make(map[int]string, len(widths))
+	dir := filepath.Dir(key)
+	format, ext := thumbnailFormat()
+
+	for _, w := range widths {
+		if w <= 0 || w >= width {
+			continue
+		}
+
+		thumb := imaging.Resize(img, w, 0, imaging.Lanczos)
+
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, thumb, format); err != nil {
+			global.Log.Warn("编码缩略图失败", zap.Int("width", w), zap.Error(err))
+			continue
+		}
+
+		thumbKey := fmt.Sprintf("%s/%s_%d.%s", dir, hash, w, ext)
+		thumbURL, err := backend.Save(ctx, thumbKey, &buf)
+		if err != nil {
+			global.Log.Warn("保存缩略图失败", zap.Int("width", w), zap.Error(err))
+			continue
+		}
+		thumbnails[w] = thumbURL
+	}
+
+	return width, height, thumbnails
+}
+
+// probeVideoDimensions 通过 ffprobe 读取视频宽高；未安装 ffprobe 时返回错误，由调用方忽略
+func probeVideoDimensions(path string) (int, int, error) {
+	out, err := exec.Command("ffprobe", "-v", "error", "-select_streams", "v:0",
+		"-show_entries", "stream=width,height", "-of", "csv=s=x:p=0", path).Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("执行ffprobe失败: %w", err)
+	}
+
+	var width, height int
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(out)), "%dx%d", &width, &height); err != nil {
+		return 0, 0, fmt.Errorf("解析视频尺寸失败: %w", err)
+	}
+	return width, height, nil
+}
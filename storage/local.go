@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"blog/global"
+)
+
+// Local 本地磁盘存储，文件写入 config.Upload.Path 下
+type Local struct {
+	basePath string
+	baseURL  string
+}
+
+// NewLocal 创建本地磁盘存储后端
+func NewLocal() *Local {
+	return &Local{
+		basePath: global.Config.Upload.Path,
+		baseURL:  fmt.Sprintf("http://%s:%d/static", global.Config.System.Host, global.Config.System.Port),
+	}
+}
+
+func (l *Local) Save(_ context.Context, key string, r io.Reader) (string, error) {
+	fullPath := filepath.Join(l.basePath, key)
+	if err := os.MkdirAll(filepath.Dir(fullPath), fs.ModePerm); err != nil {
+		return "", fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	dst, err := os.Create(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		return "", fmt.Errorf("写入文件失败: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s", l.baseURL, key), nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	if err := os.Remove(filepath.Join(l.basePath, key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除文件失败: %w", err)
+	}
+	return nil
+}
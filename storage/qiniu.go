@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"blog/global"
+
+	"github.com/qiniu/go-sdk/v7/auth/qbox"
+	qiniustorage "github.com/qiniu/go-sdk/v7/storage"
+)
+
+// Qiniu 基于七牛云对象存储的存储后端
+type Qiniu struct {
+	mac    *qbox.Mac
+	cfg    qiniustorage.Config
+	bucket string
+	domain string
+}
+
+// NewQiniu 创建七牛云存储后端
+func NewQiniu() *Qiniu {
+	cfg := global.Config.Upload.Qiniu
+
+	return &Qiniu{
+		mac:    qbox.NewMac(cfg.AccessKey, cfg.SecretKey),
+		bucket: cfg.Bucket,
+		domain: cfg.Domain,
+	}
+}
+
+func (q *Qiniu) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	putPolicy := qiniustorage.PutPolicy{Scope: q.bucket}
+	upToken := putPolicy.UploadToken(q.mac)
+
+	formUploader := qiniustorage.NewFormUploader(&q.cfg)
+	ret := qiniustorage.PutRet{}
+
+	if err := formUploader.Put(ctx, &ret, upToken, key, r, -1, nil); err != nil {
+		return "", fmt.Errorf("上传文件到七牛云失败: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s", q.domain, ret.Key), nil
+}
+
+func (q *Qiniu) Delete(ctx context.Context, key string) error {
+	bucketManager := qiniustorage.NewBucketManager(q.mac, &q.cfg)
+	if err := bucketManager.Delete(q.bucket, key); err != nil {
+		return fmt.Errorf("删除七牛云文件失败: %w", err)
+	}
+	return nil
+}
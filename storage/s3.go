@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"blog/global"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3 基于 AWS S3（或兼容协议的对象存储）的存储后端
+type S3 struct {
+	client *s3.Client
+	bucket string
+	domain string
+}
+
+// NewS3 创建 S3 存储后端
+func NewS3() *S3 {
+	cfg := global.Config.Upload.S3
+
+	client := s3.New(s3.Options{
+		Region:       cfg.Region,
+		BaseEndpoint: aws.String(cfg.Endpoint),
+		Credentials:  credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
+	})
+
+	return &S3{
+		client: client,
+		bucket: cfg.Bucket,
+		domain: cfg.Endpoint,
+	}
+}
+
+func (s *S3) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	})
+	if err != nil {
+		return "", fmt.Errorf("上传文件到S3失败: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", s.domain, s.bucket, key), nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("删除S3文件失败: %w", err)
+	}
+	return nil
+}
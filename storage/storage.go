@@ -0,0 +1,28 @@
+// Package storage 抽象上传文件的存储后端，支持本地磁盘和对象存储，
+// 由 config.Upload.Driver 选择具体实现。
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage 存储后端统一接口，key 为不含存储前缀的相对路径
+type Storage interface {
+	// Save 写入内容并返回可直接访问的 URL
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+	// Delete 删除指定 key 对应的文件
+	Delete(ctx context.Context, key string) error
+}
+
+// New 根据配置的 driver 创建对应的存储后端，未知 driver 回退到本地磁盘
+func New(driver string) Storage {
+	switch driver {
+	case "s3":
+		return NewS3()
+	case "qiniu":
+		return NewQiniu()
+	default:
+		return NewLocal()
+	}
+}
@@ -0,0 +1,97 @@
+// Package acmatcher 实现 Aho-Corasick 多模式串匹配，
+// 用于敏感词过滤场景下对文本做一次扫描。每读入一个字符都沿失败链回溯收集命中，
+// 扫描耗时为 O(n·L)，L 为最长敏感词的长度。
+package acmatcher
+
+// Matcher 敏感词自动机
+type Matcher struct {
+	root *node
+}
+
+type node struct {
+	children map[rune]*node
+	fail     *node
+	word     string // 非空表示该节点是某个模式串的终止节点
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// New 根据给定的敏感词列表构建自动机
+func New(words []string) *Matcher {
+	root := newNode()
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		cur := root
+		for _, r := range word {
+			next, ok := cur.children[r]
+			if !ok {
+				next = newNode()
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		cur.word = word
+	}
+
+	queue := make([]*node, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			fail := cur.fail
+			for fail != nil {
+				if next, ok := fail.children[r]; ok {
+					child.fail = next
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				child.fail = root
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return &Matcher{root: root}
+}
+
+// Match 扫描文本，返回命中的敏感词（去重，不保证顺序）
+func (m *Matcher) Match(text string) []string {
+	if m == nil || len(m.root.children) == 0 {
+		return nil
+	}
+
+	hits := make(map[string]struct{})
+	cur := m.root
+	for _, r := range text {
+		for cur != m.root {
+			if _, ok := cur.children[r]; ok {
+				break
+			}
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		}
+		for n := cur; n != nil && n != m.root; n = n.fail {
+			if n.word != "" {
+				hits[n.word] = struct{}{}
+			}
+		}
+	}
+
+	words := make([]string, 0, len(hits))
+	for word := range hits {
+		words = append(words, word)
+	}
+	return words
+}